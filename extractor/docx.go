@@ -0,0 +1,82 @@
+// extractor/docx.go
+package extractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+func init() { Register(docxExtractor{}) }
+
+type docxExtractor struct{}
+
+func (docxExtractor) ContentTypes() []string { return []string{docxContentType} }
+
+// Extract reads word/document.xml out of the DOCX's ZIP container and
+// concatenates the contents of every <w:t> run.
+func (docxExtractor) Extract(r io.Reader) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("error reading DOCX file: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", fmt.Errorf("error opening DOCX archive: %v", err)
+	}
+
+	for _, f := range zr.File {
+		if f.Name != "word/document.xml" {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return "", fmt.Errorf("error reading word/document.xml: %v", err)
+		}
+		defer rc.Close()
+		return extractDocxRuns(rc)
+	}
+
+	return "", fmt.Errorf("word/document.xml not found in DOCX archive")
+}
+
+func extractDocxRuns(r io.Reader) (string, error) {
+	var text strings.Builder
+	decoder := xml.NewDecoder(r)
+	inTextRun := false
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("error decoding word/document.xml: %v", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "t" {
+				inTextRun = true
+			}
+		case xml.EndElement:
+			if t.Name.Local == "t" {
+				inTextRun = false
+			}
+		case xml.CharData:
+			if inTextRun {
+				text.Write(t)
+				text.WriteString(" ")
+			}
+		}
+	}
+
+	return text.String(), nil
+}
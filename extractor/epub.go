@@ -0,0 +1,154 @@
+// extractor/epub.go
+package extractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+func init() { Register(epubExtractor{}) }
+
+type epubExtractor struct{}
+
+func (epubExtractor) ContentTypes() []string { return []string{"application/epub+zip"} }
+
+// container is META-INF/container.xml, which points to the package document
+// (OPF) that holds the manifest and spine.
+type container struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// packageDocument is the OPF package document: manifest maps item ids to
+// hrefs, and spine lists those ids in reading order.
+type packageDocument struct {
+	Manifest struct {
+		Items []struct {
+			ID   string `xml:"id,attr"`
+			Href string `xml:"href,attr"`
+		} `xml:"item"`
+	} `xml:"manifest"`
+	Spine struct {
+		ItemRefs []struct {
+			IDRef string `xml:"idref,attr"`
+		} `xml:"itemref"`
+	} `xml:"spine"`
+}
+
+// Extract walks the EPUB's ZIP container and concatenates the text of every
+// spine item, in the reading order declared by the OPF package document's
+// <spine>, rather than raw archive order.
+func (epubExtractor) Extract(r io.Reader) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("error reading EPUB file: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", fmt.Errorf("error opening EPUB archive: %v", err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	opfPath, err := rootfilePath(files)
+	if err != nil {
+		return "", err
+	}
+	pkg, err := readPackageDocument(files, opfPath)
+	if err != nil {
+		return "", err
+	}
+
+	hrefByID := make(map[string]string, len(pkg.Manifest.Items))
+	for _, item := range pkg.Manifest.Items {
+		hrefByID[item.ID] = item.Href
+	}
+
+	opfDir := path.Dir(opfPath)
+	var text strings.Builder
+	for _, ref := range pkg.Spine.ItemRefs {
+		href, ok := hrefByID[ref.IDRef]
+		if !ok {
+			continue
+		}
+		name := path.Join(opfDir, href)
+
+		f, ok := files[name]
+		if !ok {
+			continue
+		}
+		itemText, err := extractZipEntryHTML(f)
+		if err != nil {
+			return "", fmt.Errorf("error parsing EPUB spine item %s: %v", name, err)
+		}
+
+		text.WriteString(itemText)
+		text.WriteString(" ")
+	}
+
+	return text.String(), nil
+}
+
+// rootfilePath reads META-INF/container.xml to find the path to the OPF
+// package document.
+func rootfilePath(files map[string]*zip.File) (string, error) {
+	f, ok := files["META-INF/container.xml"]
+	if !ok {
+		return "", fmt.Errorf("META-INF/container.xml not found in EPUB archive")
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return "", fmt.Errorf("error reading META-INF/container.xml: %v", err)
+	}
+	defer rc.Close()
+
+	var c container
+	if err := xml.NewDecoder(rc).Decode(&c); err != nil {
+		return "", fmt.Errorf("error decoding META-INF/container.xml: %v", err)
+	}
+	if len(c.Rootfiles) == 0 {
+		return "", fmt.Errorf("no rootfile declared in META-INF/container.xml")
+	}
+
+	return c.Rootfiles[0].FullPath, nil
+}
+
+// readPackageDocument decodes the OPF package document at opfPath.
+func readPackageDocument(files map[string]*zip.File, opfPath string) (*packageDocument, error) {
+	f, ok := files[opfPath]
+	if !ok {
+		return nil, fmt.Errorf("EPUB package document %s not found in archive", opfPath)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error reading EPUB package document %s: %v", opfPath, err)
+	}
+	defer rc.Close()
+
+	var pkg packageDocument
+	if err := xml.NewDecoder(rc).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("error decoding EPUB package document %s: %v", opfPath, err)
+	}
+	return &pkg, nil
+}
+
+func extractZipEntryHTML(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	return extractHTMLText(rc)
+}
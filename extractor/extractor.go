@@ -0,0 +1,30 @@
+// extractor/extractor.go
+package extractor
+
+import "io"
+
+// Extractor turns the raw contents of an uploaded document into plain text
+// for word counting.
+type Extractor interface {
+	// ContentTypes lists the MIME types this Extractor handles.
+	ContentTypes() []string
+	// Extract reads r and returns the document's plain-text content.
+	Extract(r io.Reader) (string, error)
+}
+
+var registry = make(map[string]Extractor)
+
+// Register makes e available for every content type it reports. Built-in
+// extractors call this from an init func; callers can register their own
+// to support additional formats without touching the HTTP handler.
+func Register(e Extractor) {
+	for _, contentType := range e.ContentTypes() {
+		registry[contentType] = e
+	}
+}
+
+// ExtractorFor returns the Extractor registered for contentType, if any.
+func ExtractorFor(contentType string) (Extractor, bool) {
+	e, ok := registry[contentType]
+	return e, ok
+}
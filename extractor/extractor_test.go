@@ -0,0 +1,179 @@
+// extractor/extractor_test.go
+package extractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPlainExtractor(t *testing.T) {
+	var ext plainExtractor
+
+	got, err := ext.Extract(strings.NewReader("hello world"))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got != "hello world" {
+		t.Errorf("Extract() = %q, want %q", got, "hello world")
+	}
+
+	got, err = ext.Extract(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("Extract() on empty input error = %v", err)
+	}
+	if got != "" {
+		t.Errorf("Extract() on empty input = %q, want empty", got)
+	}
+}
+
+func TestHTMLExtractor(t *testing.T) {
+	var ext htmlExtractor
+
+	html := `<html><body><style>.a{color:red}</style><script>var x=1</script><p>Hello world</p></body></html>`
+	got, err := ext.Extract(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if strings.Contains(got, "color") || strings.Contains(got, "var x") {
+		t.Errorf("Extract() = %q, want script/style text excluded", got)
+	}
+	if !strings.Contains(got, "Hello world") {
+		t.Errorf("Extract() = %q, want it to contain %q", got, "Hello world")
+	}
+
+	got, err = ext.Extract(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("Extract() on empty input error = %v", err)
+	}
+	if got != "" {
+		t.Errorf("Extract() on empty input = %q, want empty", got)
+	}
+}
+
+func TestMarkdownExtractor(t *testing.T) {
+	var ext markdownExtractor
+
+	md := "# Title\n\nHello world.\n\n```\ncode should be skipped\n```\n"
+	got, err := ext.Extract(strings.NewReader(md))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if strings.Contains(got, "skipped") {
+		t.Errorf("Extract() = %q, want fenced code block excluded", got)
+	}
+	if !strings.Contains(got, "Hello world") {
+		t.Errorf("Extract() = %q, want it to contain %q", got, "Hello world")
+	}
+
+	got, err = ext.Extract(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("Extract() on empty input error = %v", err)
+	}
+	if got != "" {
+		t.Errorf("Extract() on empty input = %q, want empty", got)
+	}
+}
+
+func TestDocxExtractor(t *testing.T) {
+	var ext docxExtractor
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("word/document.xml")
+	if err != nil {
+		t.Fatalf("zip.Create() error = %v", err)
+	}
+	w.Write([]byte(`<w:document><w:body><w:p><w:r><w:t>Hello</w:t></w:r> <w:r><w:t>world</w:t></w:r></w:p></w:body></w:document>`))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip.Close() error = %v", err)
+	}
+
+	got, err := ext.Extract(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if !strings.Contains(got, "Hello") || !strings.Contains(got, "world") {
+		t.Errorf("Extract() = %q, want it to contain both runs", got)
+	}
+
+	if _, err := ext.Extract(strings.NewReader("not a zip")); err == nil {
+		t.Error("Extract() on malformed archive returned nil error, want error")
+	}
+
+	if _, err := ext.Extract(strings.NewReader("")); err == nil {
+		t.Error("Extract() on empty input returned nil error, want error")
+	}
+
+	var empty bytes.Buffer
+	zip.NewWriter(&empty).Close()
+	if _, err := ext.Extract(bytes.NewReader(empty.Bytes())); err == nil {
+		t.Error("Extract() on archive missing word/document.xml returned nil error, want error")
+	}
+}
+
+func TestEpubExtractor(t *testing.T) {
+	var ext epubExtractor
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	container, _ := zw.Create("META-INF/container.xml")
+	container.Write([]byte(`<container><rootfiles><rootfile full-path="OEBPS/content.opf"/></rootfiles></container>`))
+
+	opf, _ := zw.Create("OEBPS/content.opf")
+	opf.Write([]byte(`<package>
+		<manifest>
+			<item id="chap1" href="chap1.xhtml"/>
+			<item id="chap2" href="chap2.xhtml"/>
+		</manifest>
+		<spine>
+			<itemref idref="chap2"/>
+			<itemref idref="chap1"/>
+		</spine>
+	</package>`))
+
+	chap1, _ := zw.Create("OEBPS/chap1.xhtml")
+	chap1.Write([]byte(`<html><body><p>first chapter</p></body></html>`))
+	chap2, _ := zw.Create("OEBPS/chap2.xhtml")
+	chap2.Write([]byte(`<html><body><p>second chapter</p></body></html>`))
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip.Close() error = %v", err)
+	}
+
+	got, err := ext.Extract(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if strings.Index(got, "second chapter") > strings.Index(got, "first chapter") {
+		t.Errorf("Extract() = %q, want spine order (second before first) honored", got)
+	}
+
+	if _, err := ext.Extract(strings.NewReader("not a zip")); err == nil {
+		t.Error("Extract() on malformed archive returned nil error, want error")
+	}
+
+	if _, err := ext.Extract(strings.NewReader("")); err == nil {
+		t.Error("Extract() on empty input returned nil error, want error")
+	}
+
+	var noContainer bytes.Buffer
+	zip.NewWriter(&noContainer).Close()
+	if _, err := ext.Extract(bytes.NewReader(noContainer.Bytes())); err == nil {
+		t.Error("Extract() on archive missing META-INF/container.xml returned nil error, want error")
+	}
+}
+
+func TestPDFExtractor(t *testing.T) {
+	var ext pdfExtractor
+
+	if _, err := ext.Extract(strings.NewReader("not a pdf")); err == nil {
+		t.Error("Extract() on malformed input returned nil error, want error")
+	}
+
+	if _, err := ext.Extract(strings.NewReader("")); err == nil {
+		t.Error("Extract() on empty input returned nil error, want error")
+	}
+}
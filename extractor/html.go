@@ -0,0 +1,62 @@
+// extractor/html.go
+package extractor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+func init() { Register(htmlExtractor{}) }
+
+type htmlExtractor struct{}
+
+func (htmlExtractor) ContentTypes() []string { return []string{"text/html"} }
+
+func (htmlExtractor) Extract(r io.Reader) (string, error) {
+	return extractHTMLText(r)
+}
+
+// skippedText names elements whose text content isn't prose and shouldn't be
+// counted as words.
+var skippedText = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// extractHTMLText strips tags from an HTML document, keeping only the
+// tokenizer's text runs. Text inside script and style elements is discarded,
+// since it's code, not prose. It is shared with the EPUB extractor, which
+// applies it to each spine item.
+func extractHTMLText(r io.Reader) (string, error) {
+	var text strings.Builder
+	tokenizer := html.NewTokenizer(r)
+	var skipping string
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", fmt.Errorf("error tokenizing HTML: %v", err)
+			}
+			return text.String(), nil
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if skipping == "" && skippedText[string(name)] {
+				skipping = string(name)
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if skipping == string(name) {
+				skipping = ""
+			}
+		case html.TextToken:
+			if skipping != "" {
+				continue
+			}
+			text.Write(tokenizer.Text())
+			text.WriteString(" ")
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// extractor/markdown.go
+package extractor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+func init() { Register(markdownExtractor{}) }
+
+type markdownExtractor struct{}
+
+func (markdownExtractor) ContentTypes() []string { return []string{"text/markdown"} }
+
+// Extract renders the markdown to an AST and concatenates its text nodes,
+// discarding code blocks and spans so fenced snippets don't pollute word
+// frequencies.
+func (markdownExtractor) Extract(r io.Reader) (string, error) {
+	source, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("error reading markdown file: %v", err)
+	}
+
+	doc := goldmark.New().Parser().Parse(text.NewReader(source))
+
+	var out strings.Builder
+	err = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case ast.KindCodeBlock, ast.KindFencedCodeBlock, ast.KindCodeSpan:
+			return ast.WalkSkipChildren, nil
+		case ast.KindText:
+			out.Write(n.(*ast.Text).Segment.Value(source))
+			out.WriteString(" ")
+		}
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error walking markdown document: %v", err)
+	}
+
+	return out.String(), nil
+}
@@ -0,0 +1,41 @@
+// extractor/pdf.go
+package extractor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func init() { Register(pdfExtractor{}) }
+
+type pdfExtractor struct{}
+
+func (pdfExtractor) ContentTypes() []string { return []string{"application/pdf"} }
+
+func (pdfExtractor) Extract(r io.Reader) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("error reading PDF file: %v", err)
+	}
+
+	reader, err := pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", fmt.Errorf("error creating PDF reader: %v", err)
+	}
+
+	var text strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		pageText, err := page.GetPlainText(nil)
+		if err != nil {
+			continue
+		}
+		text.WriteString(pageText)
+	}
+
+	return text.String(), nil
+}
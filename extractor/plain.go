@@ -0,0 +1,21 @@
+// extractor/plain.go
+package extractor
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() { Register(plainExtractor{}) }
+
+type plainExtractor struct{}
+
+func (plainExtractor) ContentTypes() []string { return []string{"text/plain"} }
+
+func (plainExtractor) Extract(r io.Reader) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("error reading file content: %v", err)
+	}
+	return string(content), nil
+}
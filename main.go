@@ -1,56 +1,65 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"io"
+	"errors"
+	"flag"
 	"log"
 	"net/http"
-	"regexp"
-	"sort"
-	"strings"
-	"sync"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/ledongthuc/pdf"
+
+	"word-count-api/wordcounter"
 )
 
 const (
 	maxFileSize = 10 << 20 // 10 MB
-)
-
-type WordFrequency struct {
-	Word      string `json:"word"`
-	Frequency int    `json:"frequency"`
-}
 
-type Analysis struct {
-	ID          string          `json:"id"`
-	Frequencies []WordFrequency `json:"frequencies"`
-}
+	persistPath     = "analyses.gob"
+	persistInterval = 30 * time.Second
 
-type Store struct {
-	mu        sync.RWMutex
-	analyses  map[string]Analysis
-}
+	uploadWorkers = 4
+)
 
-func NewStore() *Store {
-	return &Store{
-		analyses: make(map[string]Analysis),
-	}
-}
+var (
+	ttlFlag            = flag.Duration("ttl", 24*time.Hour, "default time-to-live for a stored analysis")
+	analyzeTimeoutFlag = flag.Duration("analyze-timeout", 30*time.Second, "how long an upload may occupy a worker slot before failing with ErrBusy")
+)
 
 var (
-	store = NewStore()
-	wordRegex = regexp.MustCompile(`\b[\p{L}]+\b`)
+	store   *Store
+	counter = wordcounter.New(uploadWorkers)
 )
 
 func main() {
+	flag.Parse()
+	store = NewStore(*ttlFlag)
+
 	mux := http.NewServeMux()
 
 	// Register routes with the new ServeMux pattern matching
 	mux.HandleFunc("POST /api/upload", handleUpload)
 	mux.HandleFunc("GET /api/analysis/{id}", handleGetAnalysis)
+	mux.HandleFunc("GET /api/analysis/{id}/query", handleQueryAnalysis)
+	mux.HandleFunc("POST /api/analysis/{id}/touch", handleTouchAnalysis)
+	mux.HandleFunc("DELETE /api/analysis/{id}", handleDeleteAnalysis)
+	mux.HandleFunc("GET /analysis/{id}", handleAnalysisPage)
+
+	if err := loadSnapshot(persistPath); err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("not loading snapshot from %s: %v", persistPath, err)
+		}
+	} else {
+		log.Printf("restored analyses from %s", persistPath)
+	}
+
+	go store.Janitor()
+	go PersistLoop(persistPath, persistInterval)
+	go flushOnSignal(persistPath)
 
 	log.Printf("Server starting on :8080")
 	if err := http.ListenAndServe(":8080", mux); err != nil {
@@ -72,57 +81,42 @@ func handleUpload(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	// Validate content type
 	contentType := header.Header.Get("Content-Type")
-	if !isValidContentType(contentType) {
-		http.Error(w, "Invalid file type. Only text/plain and application/pdf are supported", http.StatusBadRequest)
-		return
-	}
 
-	// Read and process file content
-	var text string
-	if contentType == "application/pdf" {
-		text, err = extractPDFText(file)
-	} else {
-		content, err := io.ReadAll(file)
-		if err == nil {
-			text = string(content)
-		}
-	}
+	// Bound how long a single upload can occupy a worker slot, so slow
+	// extraction can't wedge the server.
+	ctx, cancel := context.WithTimeout(r.Context(), *analyzeTimeoutFlag)
+	defer cancel()
 
+	analysis, err := counter.Analyze(ctx, file, contentType)
 	if err != nil {
-		http.Error(w, "Error reading file content", http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, wordcounter.ErrUnsupportedContentType):
+			http.Error(w, "Unsupported file type: "+contentType, http.StatusBadRequest)
+		case errors.Is(err, wordcounter.ErrBusy):
+			w.Header().Set("Retry-After", strconv.Itoa(int(analyzeTimeoutFlag.Seconds())))
+			http.Error(w, "Server busy, please retry later", http.StatusServiceUnavailable)
+		default:
+			http.Error(w, "Error reading file content", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// Process text and count words
-	frequencies := processText(text)
-
 	// Generate UUID and store analysis
-	analysisID := uuid.New().String()
-	analysis := Analysis{
-		ID:          analysisID,
-		Frequencies: frequencies,
-	}
-
-	store.mu.Lock()
-	store.analyses[analysisID] = analysis
-	store.mu.Unlock()
+	analysis.ID = uuid.New().String()
+	store.Put(analysis)
 
 	// Return analysis ID
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
-		"id": analysisID,
+		"id": analysis.ID,
 	})
 }
 
 func handleGetAnalysis(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	store.mu.RLock()
-	analysis, exists := store.analyses[id]
-	store.mu.RUnlock()
-
+	analysis, exists := store.Get(id)
 	if !exists {
 		http.Error(w, "Analysis not found", http.StatusNotFound)
 		return
@@ -132,61 +126,34 @@ func handleGetAnalysis(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(analysis)
 }
 
-func isValidContentType(contentType string) bool {
-	return contentType == "text/plain" || contentType == "application/pdf"
-}
-
-func extractPDFText(file io.Reader) (string, error) {
-	// Create temporary file to store PDF content
-	content, err := io.ReadAll(file)
-	if err != nil {
-		return "", fmt.Errorf("error reading PDF file: %v", err)
-	}
+func handleTouchAnalysis(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
 
-	// Read PDF content
-	reader, err := pdf.NewReader(content)
-	if err != nil {
-		return "", fmt.Errorf("error creating PDF reader: %v", err)
+	var ttl time.Duration
+	if raw := r.URL.Query().Get("ttl"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid ttl parameter: "+raw, http.StatusBadRequest)
+			return
+		}
+		ttl = parsed
 	}
 
-	var text strings.Builder
-	for i := 1; i <= reader.NumPage(); i++ {
-		page := reader.Page(i)
-		pageText, err := page.GetPlainText()
-		if err != nil {
-			continue
-		}
-		text.WriteString(pageText)
+	if !store.Touch(id, ttl) {
+		http.Error(w, "Analysis not found", http.StatusNotFound)
+		return
 	}
 
-	return text.String(), nil
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func processText(text string) []WordFrequency {
-	// Convert to lowercase
-	text = strings.ToLower(text)
-
-	// Extract words using regex
-	words := wordRegex.FindAllString(text, -1)
-
-	// Count frequencies
-	frequencies := make(map[string]int)
-	for _, word := range words {
-		frequencies[word]++
-	}
+func handleDeleteAnalysis(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
 
-	// Convert to slice and sort
-	var result []WordFrequency
-	for word, freq := range frequencies {
-		result = append(result, WordFrequency{
-			Word:      word,
-			Frequency: freq,
-		})
+	if !store.Delete(id) {
+		http.Error(w, "Analysis not found", http.StatusNotFound)
+		return
 	}
 
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].Frequency > result[j].Frequency
-	})
-
-	return result
-}
\ No newline at end of file
+	w.WriteHeader(http.StatusNoContent)
+}
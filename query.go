@@ -0,0 +1,148 @@
+// query.go
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+
+	"word-count-api/wordcounter"
+)
+
+// jsonpCallbackPattern restricts the jsonp callback query parameter to a
+// dotted identifier, so it can't break out of the function-call position it's
+// interpolated into and inject arbitrary script.
+var jsonpCallbackPattern = regexp.MustCompile(`^[a-zA-Z_$][\w$]*(\.[a-zA-Z_$][\w$]*)*$`)
+
+// handleQueryAnalysis serves GET /api/analysis/{id}/query, which narrows an
+// existing analysis's word frequencies by regexp, minimum frequency, and
+// stopwords, then renders the result in the requested format.
+func handleQueryAnalysis(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	analysis, exists := store.Get(id)
+	if !exists {
+		http.Error(w, "Analysis not found", http.StatusNotFound)
+		return
+	}
+
+	opts, err := parseQueryOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := wordcounter.Query(analysis.Frequencies, opts)
+	writeQueryResult(w, r, result)
+}
+
+func parseQueryOptions(r *http.Request) (wordcounter.QueryOptions, error) {
+	query := r.URL.Query()
+	var opts wordcounter.QueryOptions
+
+	if q := query.Get("q"); q != "" {
+		pattern, err := regexp.Compile(q)
+		if err != nil {
+			var synErr *syntax.Error
+			if errors.As(err, &synErr) {
+				return opts, fmt.Errorf("invalid q regexp at %q: %s", synErr.Expr, synErr.Code)
+			}
+			return opts, fmt.Errorf("invalid q regexp: %v", err)
+		}
+		opts.Pattern = pattern
+	}
+
+	if topParam := query.Get("top"); topParam != "" {
+		top, err := strconv.Atoi(topParam)
+		if err != nil || top < 0 {
+			return opts, fmt.Errorf("invalid top parameter: %q", topParam)
+		}
+		opts.Top = top
+	}
+
+	if minParam := query.Get("min"); minParam != "" {
+		min, err := strconv.Atoi(minParam)
+		if err != nil || min < 0 {
+			return opts, fmt.Errorf("invalid min parameter: %q", minParam)
+		}
+		opts.Min = min
+	}
+
+	opts.Stopwords = wordcounter.ParseStopwords(query.Get("stopwords"))
+
+	return opts, nil
+}
+
+// writeQueryResult encodes result in the format named by the format query
+// parameter (json, jsonp, csv, or tsv; json is the default).
+func writeQueryResult(w http.ResponseWriter, r *http.Request, result []wordcounter.WordFrequency) {
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		writeDelimited(w, result, ',')
+	case "tsv":
+		writeDelimited(w, result, '\t')
+	case "jsonp":
+		writeJSONP(w, r, result)
+	default:
+		writeJSON(w, r, result)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, result []wordcounter.WordFrequency) {
+	data, err := marshalQueryResult(r, result)
+	if err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeJSONP(w http.ResponseWriter, r *http.Request, result []wordcounter.WordFrequency) {
+	callback := r.FormValue("callback")
+	if callback == "" {
+		http.Error(w, "callback parameter required for jsonp format", http.StatusBadRequest)
+		return
+	}
+	if !jsonpCallbackPattern.MatchString(callback) {
+		http.Error(w, "callback parameter must be a valid identifier", http.StatusBadRequest)
+		return
+	}
+
+	data, err := marshalQueryResult(r, result)
+	if err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/javascript")
+	fmt.Fprintf(w, "%s(%s)", callback, data)
+}
+
+func marshalQueryResult(r *http.Request, result []wordcounter.WordFrequency) ([]byte, error) {
+	if r.URL.Query().Get("pretty") == "1" {
+		return json.MarshalIndent(result, "", "  ")
+	}
+	return json.Marshal(result)
+}
+
+func writeDelimited(w http.ResponseWriter, result []wordcounter.WordFrequency, delimiter rune) {
+	contentType := "text/csv"
+	if delimiter == '\t' {
+		contentType = "text/tab-separated-values"
+	}
+	w.Header().Set("Content-Type", contentType)
+
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+	cw.Write([]string{"word", "frequency"})
+	for _, wf := range result {
+		cw.Write([]string{wf.Word, strconv.Itoa(wf.Frequency)})
+	}
+	cw.Flush()
+}
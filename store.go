@@ -0,0 +1,298 @@
+// store.go
+package main
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"word-count-api/wordcounter"
+)
+
+type Analysis = wordcounter.Analysis
+
+// Store holds analyses in memory, expiring each one a fixed time after it
+// was last stored or touched.
+type Store struct {
+	mu         sync.Mutex
+	analyses   map[string]Analysis
+	items      map[string]*ttlItem
+	expiries   ttlHeap
+	defaultTTL time.Duration
+	wake       chan struct{}
+}
+
+// NewStore returns a Store that expires analyses defaultTTL after they're
+// put or last touched.
+func NewStore(defaultTTL time.Duration) *Store {
+	return &Store{
+		analyses:   make(map[string]Analysis),
+		items:      make(map[string]*ttlItem),
+		defaultTTL: defaultTTL,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// ttlItem tracks one analysis's place in the expiry heap and the timer that
+// wakes the janitor when this item's deadline changes.
+type ttlItem struct {
+	id        string
+	expiresAt time.Time
+	timer     *time.Timer
+	index     int
+}
+
+// ttlHeap is a container/heap.Interface ordered by ttlItem.expiresAt, so the
+// janitor can find and remove the next entry to expire in O(log n) instead
+// of scanning every analysis.
+type ttlHeap []*ttlItem
+
+func (h ttlHeap) Len() int            { return len(h) }
+func (h ttlHeap) Less(i, j int) bool  { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h ttlHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i]; h[i].index = i; h[j].index = j }
+func (h *ttlHeap) Push(x any)         { item := x.(*ttlItem); item.index = len(*h); *h = append(*h, item) }
+func (h *ttlHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
+
+// Put stores analysis under the store's default TTL.
+func (s *Store) Put(analysis Analysis) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	analysis.ExpiresAt = time.Now().Add(s.defaultTTL)
+	s.analyses[analysis.ID] = analysis
+	s.scheduleLocked(analysis.ID, analysis.ExpiresAt)
+}
+
+// Get returns the analysis stored under id, if it hasn't expired.
+func (s *Store) Get(id string) (Analysis, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	analysis, exists := s.analyses[id]
+	return analysis, exists
+}
+
+// Touch resets id's expiry to d from now, or to the store's default TTL if d
+// is zero. It reports whether id existed.
+func (s *Store) Touch(id string, d time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	analysis, exists := s.analyses[id]
+	if !exists {
+		return false
+	}
+	if d == 0 {
+		d = s.defaultTTL
+	}
+	analysis.ExpiresAt = time.Now().Add(d)
+	s.analyses[id] = analysis
+	s.scheduleLocked(id, analysis.ExpiresAt)
+	return true
+}
+
+// Delete removes id before its TTL elapses. It reports whether id existed.
+func (s *Store) Delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeLocked(id)
+}
+
+// scheduleLocked adds id to the expiry heap (or repositions it, if already
+// present) and arms a per-entry *time.Timer, following the deadline-reset
+// pattern: stop any existing timer, drain it if it already fired, then
+// reschedule via time.AfterFunc. The timer doesn't expire the entry itself;
+// it wakes the janitor so a Touch that shortens the deadline is noticed
+// before the janitor's current sleep would otherwise end.
+func (s *Store) scheduleLocked(id string, expiresAt time.Time) {
+	item, exists := s.items[id]
+	if !exists {
+		item = &ttlItem{id: id}
+		s.items[id] = item
+		heap.Push(&s.expiries, item)
+	}
+	item.expiresAt = expiresAt
+	heap.Fix(&s.expiries, item.index)
+
+	if item.timer != nil && !item.timer.Stop() {
+		select {
+		case <-item.timer.C:
+		default:
+		}
+	}
+	item.timer = time.AfterFunc(time.Until(expiresAt), s.wakeJanitor)
+
+	s.wakeJanitor()
+}
+
+func (s *Store) removeLocked(id string) bool {
+	item, exists := s.items[id]
+	if !exists {
+		return false
+	}
+	item.timer.Stop()
+	heap.Remove(&s.expiries, item.index)
+	delete(s.items, id)
+	_, existed := s.analyses[id]
+	delete(s.analyses, id)
+	return existed
+}
+
+func (s *Store) wakeJanitor() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Janitor expires analyses as their deadlines pass. It sleeps until the
+// earliest ExpiresAt in the heap, popping and removing every entry whose
+// deadline has arrived in O(log n) per removal, and wakes early whenever a
+// Put or Touch moves that deadline sooner.
+func (s *Store) Janitor() {
+	const idleWait = time.Hour
+
+	timer := time.NewTimer(idleWait)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		for s.expiries.Len() > 0 && !s.expiries[0].expiresAt.After(now) {
+			s.removeLocked(s.expiries[0].id)
+		}
+
+		wait := idleWait
+		if s.expiries.Len() > 0 {
+			wait = time.Until(s.expiries[0].expiresAt)
+		}
+		s.mu.Unlock()
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-timer.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// ExportedStore is the on-disk representation of a Store snapshot. It is
+// exported (rather than Store itself) so new fields can be added to Analysis
+// without breaking gob-decoding of snapshots written by older binaries.
+type ExportedStore struct {
+	Analyses map[string]Analysis
+}
+
+// Snapshot returns a copy of the store's analyses suitable for persisting.
+func (s *Store) Snapshot() *ExportedStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	analyses := make(map[string]Analysis, len(s.analyses))
+	for id, analysis := range s.analyses {
+		analyses[id] = analysis
+	}
+	return &ExportedStore{Analyses: analyses}
+}
+
+// Restore replaces the store's analyses with the contents of a snapshot,
+// re-arming an expiry timer for each one from its persisted ExpiresAt (the
+// timers themselves don't survive a restart).
+func (s *Store) Restore(exported *ExportedStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.analyses = exported.Analyses
+	s.items = make(map[string]*ttlItem)
+	s.expiries = nil
+	for id, analysis := range s.analyses {
+		s.scheduleLocked(id, analysis.ExpiresAt)
+	}
+}
+
+// loadSnapshot decodes a gob-encoded snapshot from path into the store, if
+// the file exists.
+func loadSnapshot(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var exported ExportedStore
+	if err := gob.NewDecoder(f).Decode(&exported); err != nil {
+		return fmt.Errorf("error decoding snapshot: %v", err)
+	}
+	store.Restore(&exported)
+	return nil
+}
+
+// writeSnapshot atomically writes the store's current contents to path by
+// encoding to path+".tmp" and renaming it into place.
+func writeSnapshot(path string) error {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot temp file: %v", err)
+	}
+
+	if err := gob.NewEncoder(f).Encode(store.Snapshot()); err != nil {
+		f.Close()
+		return fmt.Errorf("error encoding snapshot: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error closing snapshot temp file: %v", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("error renaming snapshot into place: %v", err)
+	}
+	return nil
+}
+
+// PersistLoop periodically flushes the store to path until the process exits.
+func PersistLoop(path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := writeSnapshot(path); err != nil {
+			log.Printf("error persisting store: %v", err)
+		}
+	}
+}
+
+// flushOnSignal waits for SIGINT/SIGTERM and flushes the store once more
+// before letting the process exit, so a graceful shutdown doesn't lose the
+// analyses accumulated since the last PersistLoop tick.
+func flushOnSignal(path string) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	<-sigCh
+	log.Printf("received shutdown signal, flushing store to %s", path)
+	if err := writeSnapshot(path); err != nil {
+		log.Printf("error flushing store on shutdown: %v", err)
+	}
+	os.Exit(0)
+}
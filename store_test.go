@@ -0,0 +1,35 @@
+// store_test.go
+package main
+
+import (
+	"testing"
+	"time"
+
+	"word-count-api/wordcounter"
+)
+
+func TestStoreSnapshotRestore(t *testing.T) {
+	src := NewStore(time.Hour)
+	src.Put(wordcounter.Analysis{
+		ID:          "abc",
+		Frequencies: []wordcounter.WordFrequency{{Word: "hello", Frequency: 2}},
+	})
+
+	dst := NewStore(time.Hour)
+	dst.Restore(src.Snapshot())
+
+	got, exists := dst.Get("abc")
+	if !exists {
+		t.Fatal("Restore() did not carry over analysis \"abc\"")
+	}
+	if got.ID != "abc" || len(got.Frequencies) != 1 || got.Frequencies[0].Word != "hello" {
+		t.Errorf("Restore() = %+v, want analysis for id \"abc\" with frequency \"hello\"", got)
+	}
+
+	if !dst.Touch("abc", 0) {
+		t.Error("Touch() on restored analysis returned false, want true")
+	}
+	if dst.Touch("missing", 0) {
+		t.Error("Touch() on missing analysis returned true, want false")
+	}
+}
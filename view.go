@@ -0,0 +1,201 @@
+// view.go
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"word-count-api/wordcounter"
+)
+
+const (
+	defaultPageSize = 50
+	histogramSize   = 50
+	barHeight       = 14
+)
+
+// templates holds every page under web/templates, parsed once at startup.
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"Inc":       func(i int) int { return i + 1 },
+	"More":      func(a, b int) int { return a * b },
+	"HumanUnit": humanUnit,
+}).ParseGlob("web/templates/*.html"))
+
+type histogramBar struct {
+	Word      string
+	Frequency int
+	Percent   int
+	Y         int
+}
+
+type analysisPageData struct {
+	ID              string
+	Page            int
+	Size            int
+	TotalPages      int
+	HasPrev         bool
+	PrevPage        int
+	HasNext         bool
+	Sort            string
+	Dir             string
+	Rows            []wordcounter.WordFrequency
+	Histogram       []histogramBar
+	HistogramHeight int
+}
+
+// handleAnalysisPage serves GET /analysis/{id}, an HTML view of an
+// analysis's word frequencies with pagination and a histogram of the top
+// words, for browsing without the JSON API.
+func handleAnalysisPage(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	analysis, exists := store.Get(id)
+	if !exists {
+		http.Error(w, "Analysis not found", http.StatusNotFound)
+		return
+	}
+
+	page := queryIntParam(r, "page", 1)
+	if page < 1 {
+		page = 1
+	}
+	size := queryIntParam(r, "size", defaultPageSize)
+	if size < 1 {
+		size = defaultPageSize
+	}
+
+	sortBy := r.URL.Query().Get("sort")
+	dir := r.URL.Query().Get("dir")
+	rows := sortFrequencies(analysis.Frequencies, sortBy, dir)
+
+	totalPages := (len(rows) + size - 1) / size
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	if page > totalPages {
+		page = totalPages
+	}
+
+	start := (page - 1) * size
+	if start > len(rows) {
+		start = len(rows)
+	}
+	end := start + size
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	histogram := buildHistogram(analysis.Frequencies, histogramSize)
+
+	data := analysisPageData{
+		ID:              analysis.ID,
+		Page:            page,
+		Size:            size,
+		TotalPages:      totalPages,
+		HasPrev:         page > 1,
+		PrevPage:        page - 1,
+		HasNext:         page < totalPages,
+		Sort:            sortBy,
+		Dir:             dir,
+		Rows:            rows[start:end],
+		Histogram:       histogram,
+		HistogramHeight: len(histogram)*barHeight + 2*barHeight,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ExecuteTemplate(w, "analysis.html", data); err != nil {
+		http.Error(w, "Error rendering page", http.StatusInternalServerError)
+	}
+}
+
+func queryIntParam(r *http.Request, key string, fallback int) int {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+// sortFrequencies returns a sorted copy of frequencies. sortBy is "word" or
+// "frequency" (default); dir is "asc" or "desc" (default depends on sortBy,
+// matching the order ProcessText already returns for frequency).
+func sortFrequencies(frequencies []wordcounter.WordFrequency, sortBy, dir string) []wordcounter.WordFrequency {
+	rows := make([]wordcounter.WordFrequency, len(frequencies))
+	copy(rows, frequencies)
+
+	if sortBy == "" {
+		sortBy = "frequency"
+	}
+	if dir == "" {
+		if sortBy == "frequency" {
+			dir = "desc"
+		} else {
+			dir = "asc"
+		}
+	}
+
+	switch sortBy {
+	case "word":
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].Word < rows[j].Word })
+	default:
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].Frequency < rows[j].Frequency })
+	}
+
+	if dir == "desc" {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	return rows
+}
+
+// buildHistogram returns the top limit words by frequency (independent of
+// any table sort), with bar widths scaled relative to the largest frequency.
+func buildHistogram(frequencies []wordcounter.WordFrequency, limit int) []histogramBar {
+	if limit > len(frequencies) {
+		limit = len(frequencies)
+	}
+	top := frequencies[:limit]
+
+	maxFrequency := 0
+	for _, wf := range top {
+		if wf.Frequency > maxFrequency {
+			maxFrequency = wf.Frequency
+		}
+	}
+
+	bars := make([]histogramBar, limit)
+	for i, wf := range top {
+		percent := 0
+		if maxFrequency > 0 {
+			percent = wf.Frequency * 100 / maxFrequency
+		}
+		bars[i] = histogramBar{
+			Word:      wf.Word,
+			Frequency: wf.Frequency,
+			Percent:   percent,
+			Y:         (i+1)*barHeight - barHeight/3,
+		}
+	}
+	return bars
+}
+
+// humanUnit formats large frequency counts compactly, e.g. 12500 -> "12.5k".
+func humanUnit(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return strconv.Itoa(n)
+	}
+}
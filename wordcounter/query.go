@@ -0,0 +1,67 @@
+// wordcounter/query.go
+package wordcounter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// QueryOptions narrows a set of WordFrequency results.
+type QueryOptions struct {
+	// Pattern, if non-nil, is matched against each word; non-matches are
+	// dropped.
+	Pattern *regexp.Regexp
+	// Top, if positive, caps the number of results returned.
+	Top int
+	// Min is the minimum frequency a word must have to be kept.
+	Min int
+	// Stopwords holds words to exclude from the results.
+	Stopwords map[string]struct{}
+}
+
+// Query filters and truncates frequencies according to opts. frequencies is
+// assumed to already be sorted by frequency descending, as ProcessText
+// returns it, so truncating to Top after filtering still yields the top N
+// matches by frequency.
+func Query(frequencies []WordFrequency, opts QueryOptions) []WordFrequency {
+	var result []WordFrequency
+	for _, wf := range frequencies {
+		if wf.Frequency < opts.Min {
+			continue
+		}
+		if _, stop := opts.Stopwords[wf.Word]; stop {
+			continue
+		}
+		if opts.Pattern != nil && !opts.Pattern.MatchString(wf.Word) {
+			continue
+		}
+		result = append(result, wf)
+	}
+
+	if opts.Top > 0 && len(result) > opts.Top {
+		result = result[:opts.Top]
+	}
+	return result
+}
+
+// ParseStopwords interprets the stopwords query parameter: "en" selects the
+// bundled English list, "none" (or empty) disables filtering, and anything
+// else is treated as a comma-separated custom list.
+func ParseStopwords(param string) map[string]struct{} {
+	switch param {
+	case "", "none":
+		return nil
+	case "en":
+		return newStopwordSet(stopwordsEN)
+	default:
+		return newStopwordSet(strings.Split(param, ","))
+	}
+}
+
+func newStopwordSet(words []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		set[strings.ToLower(strings.TrimSpace(word))] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,91 @@
+// wordcounter/query_test.go
+package wordcounter
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	frequencies := []WordFrequency{
+		{Word: "the", Frequency: 10},
+		{Word: "quick", Frequency: 5},
+		{Word: "fox", Frequency: 3},
+		{Word: "brown", Frequency: 1},
+	}
+
+	tests := []struct {
+		name string
+		opts QueryOptions
+		want []WordFrequency
+	}{
+		{
+			name: "no filters",
+			opts: QueryOptions{},
+			want: frequencies,
+		},
+		{
+			name: "min frequency",
+			opts: QueryOptions{Min: 3},
+			want: []WordFrequency{
+				{Word: "the", Frequency: 10},
+				{Word: "quick", Frequency: 5},
+				{Word: "fox", Frequency: 3},
+			},
+		},
+		{
+			name: "top",
+			opts: QueryOptions{Top: 2},
+			want: []WordFrequency{
+				{Word: "the", Frequency: 10},
+				{Word: "quick", Frequency: 5},
+			},
+		},
+		{
+			name: "pattern",
+			opts: QueryOptions{Pattern: regexp.MustCompile("^f")},
+			want: []WordFrequency{
+				{Word: "fox", Frequency: 3},
+			},
+		},
+		{
+			name: "stopwords",
+			opts: QueryOptions{Stopwords: map[string]struct{}{"the": {}}},
+			want: []WordFrequency{
+				{Word: "quick", Frequency: 5},
+				{Word: "fox", Frequency: 3},
+				{Word: "brown", Frequency: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Query(frequencies, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStopwords(t *testing.T) {
+	if got := ParseStopwords(""); got != nil {
+		t.Errorf("ParseStopwords(%q) = %v, want nil", "", got)
+	}
+	if got := ParseStopwords("none"); got != nil {
+		t.Errorf("ParseStopwords(%q) = %v, want nil", "none", got)
+	}
+
+	en := ParseStopwords("en")
+	if _, ok := en["the"]; !ok {
+		t.Errorf("ParseStopwords(%q) missing expected word %q", "en", "the")
+	}
+
+	custom := ParseStopwords("Foo, BAR , baz")
+	want := map[string]struct{}{"foo": {}, "bar": {}, "baz": {}}
+	if !reflect.DeepEqual(custom, want) {
+		t.Errorf("ParseStopwords(%q) = %v, want %v", "Foo, BAR , baz", custom, want)
+	}
+}
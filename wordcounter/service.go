@@ -0,0 +1,76 @@
+// wordcounter/service.go
+package wordcounter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"word-count-api/extractor"
+)
+
+var (
+	// ErrBusy is returned by Service.Analyze when the caller's context is
+	// done before a worker slot frees up.
+	ErrBusy = errors.New("wordcounter: service busy, try again later")
+	// ErrUnsupportedContentType is returned by Service.Analyze when no
+	// extractor is registered for the given content type.
+	ErrUnsupportedContentType = errors.New("wordcounter: unsupported content type")
+)
+
+// Analysis is the result of counting words in a single uploaded document.
+type Analysis struct {
+	ID          string          `json:"id"`
+	Frequencies []WordFrequency `json:"frequencies"`
+	ExpiresAt   time.Time       `json:"expiresAt"`
+}
+
+// Service bounds concurrent document analysis to a fixed number of workers,
+// so a burst of large uploads can't saturate CPU and memory.
+type Service struct {
+	sem chan struct{}
+}
+
+// New returns a Service that analyzes at most workers documents concurrently.
+func New(workers int) *Service {
+	return &Service{sem: make(chan struct{}, workers)}
+}
+
+// Analyze extracts text from r using the extractor registered for
+// contentType and counts word frequencies. It blocks waiting for a free
+// worker slot; if ctx is done first, it returns ErrBusy instead of
+// processing the document. New formats are supported by registering an
+// extractor.Extractor, not by changing Analyze.
+func (s *Service) Analyze(ctx context.Context, r io.Reader, contentType string) (Analysis, error) {
+	ext, ok := extractor.ExtractorFor(contentType)
+	if !ok {
+		return Analysis{}, ErrUnsupportedContentType
+	}
+
+	if ctx.Err() != nil {
+		return Analysis{}, ErrBusy
+	}
+
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return Analysis{}, ErrBusy
+	}
+	defer func() { <-s.sem }()
+
+	// The select above can race a just-acquired slot against an
+	// already-expired ctx; always surface that as ErrBusy rather than the
+	// raw ctx.Err(), so callers can rely on errors.Is(err, ErrBusy).
+	if ctx.Err() != nil {
+		return Analysis{}, ErrBusy
+	}
+
+	text, err := ext.Extract(r)
+	if err != nil {
+		return Analysis{}, fmt.Errorf("error extracting text: %v", err)
+	}
+
+	return Analysis{Frequencies: ProcessText(text)}, nil
+}